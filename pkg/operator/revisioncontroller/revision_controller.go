@@ -13,15 +13,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
-	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/workqueue"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 
+	"github.com/openshift/library-go/pkg/controller/factory"
 	"github.com/openshift/library-go/pkg/operator/condition"
 	"github.com/openshift/library-go/pkg/operator/events"
 	"github.com/openshift/library-go/pkg/operator/management"
@@ -29,16 +26,56 @@ import (
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
 )
 
-const revisionControllerWorkQueueKey = "key"
-
 // LatestRevisionClient is an operator client for an operator status with a latest revision field.
 type LatestRevisionClient interface {
 	v1helpers.OperatorClient
 
-	// GetLatestRevisionState returns the spec, status and latest revision.
-	GetLatestRevisionState() (spec *operatorv1.OperatorSpec, status *operatorv1.OperatorStatus, rev int32, rv string, err error)
-	// UpdateLatestRevisionOperatorStatus updates the status with the given latestAvailableRevision and the by applying the given updateFuncs.
+	// GetLatestRevisionState returns the spec, status, the latest revision whose content has been fully synced
+	// (LatestAvailableRevision), the latest revision number that has been reserved but may still be in flight
+	// (LatestKnownRevision), and the resourceVersion.
+	GetLatestRevisionState(ctx context.Context) (spec *operatorv1.OperatorSpec, status *operatorv1.OperatorStatus, latestAvailableRevision, latestKnownRevision int32, rv string, err error)
+	// UpdateLatestRevisionOperatorStatus updates the status with the given latestAvailableRevision, applying the given updateFuncs.
+	// This must only be called once a revision has been fully synced and verified to match its source content.
+	UpdateLatestRevisionOperatorStatus(ctx context.Context, latestAvailableRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error)
+	// UpdateLatestKnownRevisionOperatorStatus records that latestKnownRevision has been reserved. It is called before
+	// the revision-status configmap or any content for that revision has been written.
+	UpdateLatestKnownRevisionOperatorStatus(ctx context.Context, latestKnownRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error)
+}
+
+// LegacyLatestRevisionClient is LatestRevisionClient without the context.Context parameters. Via
+// NewLatestRevisionClientShim, consumers can implement the latest-known-revision methods without also adding
+// context.Context plumbing in the same change that picks up this library-go bump. A client that lacks the
+// latestKnownRevision return value and UpdateLatestKnownRevisionOperatorStatus does not satisfy it.
+//
+// Deprecated: implement LatestRevisionClient directly; this shim will be removed once consumers migrate.
+type LegacyLatestRevisionClient interface {
+	v1helpers.OperatorClient
+
+	GetLatestRevisionState() (spec *operatorv1.OperatorSpec, status *operatorv1.OperatorStatus, latestAvailableRevision, latestKnownRevision int32, rv string, err error)
 	UpdateLatestRevisionOperatorStatus(latestAvailableRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error)
+	UpdateLatestKnownRevisionOperatorStatus(latestKnownRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error)
+}
+
+// NewLatestRevisionClientShim adapts a LegacyLatestRevisionClient to LatestRevisionClient by discarding the
+// context on every call. It does not honor cancellation; migrate the underlying client to stop relying on it.
+func NewLatestRevisionClientShim(legacy LegacyLatestRevisionClient) LatestRevisionClient {
+	return legacyLatestRevisionClientShim{LegacyLatestRevisionClient: legacy}
+}
+
+type legacyLatestRevisionClientShim struct {
+	LegacyLatestRevisionClient
+}
+
+func (l legacyLatestRevisionClientShim) GetLatestRevisionState(ctx context.Context) (*operatorv1.OperatorSpec, *operatorv1.OperatorStatus, int32, int32, string, error) {
+	return l.LegacyLatestRevisionClient.GetLatestRevisionState()
+}
+
+func (l legacyLatestRevisionClientShim) UpdateLatestRevisionOperatorStatus(ctx context.Context, latestAvailableRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error) {
+	return l.LegacyLatestRevisionClient.UpdateLatestRevisionOperatorStatus(latestAvailableRevision, updateFuncs...)
+}
+
+func (l legacyLatestRevisionClientShim) UpdateLatestKnownRevisionOperatorStatus(ctx context.Context, latestKnownRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error) {
+	return l.LegacyLatestRevisionClient.UpdateLatestKnownRevisionOperatorStatus(latestKnownRevision, updateFuncs...)
 }
 
 // RevisionController is a controller that watches a set of configmaps and secrets and them against a revision snapshot
@@ -56,8 +93,15 @@ type RevisionController struct {
 	configMapGetter corev1client.ConfigMapsGetter
 	secretGetter    corev1client.SecretsGetter
 
-	cachesToSync  []cache.InformerSynced
-	queue         workqueue.RateLimitingInterface
+	// preconditionFulfilledFn, if set, must return true before a new revision is triggered. This lets operators
+	// delay revision creation until, e.g., observed config has converged or nodes are otherwise ready for it.
+	preconditionFulfilledFn PreconditionFunc
+	// contentSourceFn, if set, supplies additional configmaps/secrets to fold into a revision at creation time,
+	// for content that is computed rather than copied from a pre-staged source object.
+	contentSourceFn ContentSourceFunc
+	// driftPolicy controls how drift of the current revision's copies from their source is handled.
+	driftPolicy DriftPolicy
+
 	eventRecorder events.Recorder
 }
 
@@ -66,6 +110,65 @@ type RevisionResource struct {
 	Optional bool
 }
 
+// PreconditionFunc is consulted immediately before a new revision is triggered. Returning false delays revision
+// creation until a subsequent sync finds it fulfilled; returning an error is treated like any other sync error.
+//
+// A subsequent sync is only guaranteed by the periodic resync (see NewRevisionController); the controller's
+// informers watch the configured configmaps/secrets and the operator status, none of which necessarily change
+// when a precondition like node readiness becomes fulfilled. If fulfillment can take longer than the resync
+// period, the caller is responsible for requeuing the controller (e.g. via its own informer event handler)
+// when the precondition's dependencies change.
+type PreconditionFunc func(ctx context.Context) (bool, error)
+
+// ContentSourceFunc computes extra content for the given revision. The returned configmaps/secrets are applied
+// into the target namespace under the same ownerRef as the statically configured copies, and are included in
+// the identity check that decides whether the revision is current.
+//
+// It must be a pure, stable function of revision: isLatestRevisionCurrent re-invokes it for any revision it
+// checks, including old, already-settled ones, and compares its output against what was persisted when that
+// revision was created. A ContentSourceFunc that instead reflects current cluster state rather than the state
+// at the time revision N was created will make an untouched revision N look drifted or changed, and
+// DriftPolicyHeal/createRevisionIfNeeded will happily overwrite N's copies with that newer state.
+type ContentSourceFunc func(ctx context.Context, revision int32) (extraConfigMaps []corev1.ConfigMap, extraSecrets []corev1.Secret, err error)
+
+// DriftPolicy controls how RevisionController reacts when it finds that the current revision's configmap/secret
+// copies no longer exist or no longer match their source, despite the source itself being unchanged.
+type DriftPolicy int
+
+const (
+	// DriftPolicyHeal re-syncs the current revision's copies in place, leaving LatestAvailableRevision unchanged.
+	// This is the default, since consumers such as installer controllers key off the revision number and a bump
+	// would force them to roll out a new, otherwise-identical revision just to recover from the drift.
+	DriftPolicyHeal DriftPolicy = iota
+	// DriftPolicyBumpRevision triggers creation of a new revision instead of repairing the current one in place.
+	DriftPolicyBumpRevision
+)
+
+// RevisionControllerOption customizes a RevisionController at construction time.
+type RevisionControllerOption func(*RevisionController)
+
+// WithPreconditionFunc sets a precondition that must be fulfilled before a new revision is triggered.
+func WithPreconditionFunc(fn PreconditionFunc) RevisionControllerOption {
+	return func(c *RevisionController) {
+		c.preconditionFulfilledFn = fn
+	}
+}
+
+// WithDriftPolicy sets how drift of the current revision's copies from their source is handled. The default is
+// DriftPolicyHeal.
+func WithDriftPolicy(policy DriftPolicy) RevisionControllerOption {
+	return func(c *RevisionController) {
+		c.driftPolicy = policy
+	}
+}
+
+// WithContentSourceFunc sets a source of extra, dynamically computed content to fold into every revision.
+func WithContentSourceFunc(fn ContentSourceFunc) RevisionControllerOption {
+	return func(c *RevisionController) {
+		c.contentSourceFn = fn
+	}
+}
+
 // NewRevisionController create a new revision controller.
 func NewRevisionController(
 	targetNamespace string,
@@ -76,7 +179,8 @@ func NewRevisionController(
 	configMapGetter corev1client.ConfigMapsGetter,
 	secretGetter corev1client.SecretsGetter,
 	eventRecorder events.Recorder,
-) *RevisionController {
+	options ...RevisionControllerOption,
+) factory.Controller {
 	c := &RevisionController{
 		targetNamespace: targetNamespace,
 		configMaps:      configMaps,
@@ -86,52 +190,97 @@ func NewRevisionController(
 		configMapGetter: configMapGetter,
 		secretGetter:    secretGetter,
 		eventRecorder:   eventRecorder.WithComponentSuffix("revision-controller"),
-
-		queue: workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "RevisionController"),
+	}
+	for _, o := range options {
+		o(c)
 	}
 
-	operatorClient.Informer().AddEventHandler(c.eventHandler())
-	kubeInformersForTargetNamespace.Core().V1().ConfigMaps().Informer().AddEventHandler(c.eventHandler())
-	kubeInformersForTargetNamespace.Core().V1().Secrets().Informer().AddEventHandler(c.eventHandler())
-
-	c.cachesToSync = append(c.cachesToSync, operatorClient.Informer().HasSynced)
-	c.cachesToSync = append(c.cachesToSync, kubeInformersForTargetNamespace.Core().V1().ConfigMaps().Informer().HasSynced)
-	c.cachesToSync = append(c.cachesToSync, kubeInformersForTargetNamespace.Core().V1().Secrets().Informer().HasSynced)
-
-	return c
+	return factory.New().
+		WithSync(c.sync).
+		// Resync periodically so a PreconditionFunc whose dependencies aren't covered by the informers below
+		// (e.g. node readiness) still gets re-evaluated once it becomes fulfilled.
+		ResyncEvery(time.Minute).
+		WithInformers(
+			operatorClient.Informer(),
+			kubeInformersForTargetNamespace.Core().V1().ConfigMaps().Informer(),
+			kubeInformersForTargetNamespace.Core().V1().Secrets().Informer(),
+		).
+		ToController("RevisionController", eventRecorder)
 }
 
 // createRevisionIfNeeded takes care of creating content for the static pods to use.
-// returns whether or not requeue and if an error happened when updating status.  Normally it updates status itself.
-func (c RevisionController) createRevisionIfNeeded(latestAvailableRevision int32, resourceVersion string) (bool, error) {
-	isLatestRevisionCurrent, reason := c.isLatestRevisionCurrent(latestAvailableRevision)
+// returns whether or not to requeue, and an error if the precondition check, a status update, or the re-check of
+// the new revision's content failed. Normally it updates status itself. Drift of the current revision's copies from their source is handled separately by handleRevisionDrift,
+// which sync() calls before this so that DriftPolicyHeal has already repaired an unchanged-but-drifted revision
+// by the time isLatestRevisionCurrent is checked here.
+func (c RevisionController) createRevisionIfNeeded(ctx context.Context, latestAvailableRevision, latestKnownRevision int32, resourceVersion string) (bool, error) {
+	isLatestRevisionCurrent, reason := c.isLatestRevisionCurrent(ctx, latestAvailableRevision)
 
 	// check to make sure that the latestRevision has the exact content we expect.  No mutation here, so we start creating the next Revision only when it is required
 	if isLatestRevisionCurrent {
 		return false, nil
 	}
 
+	if c.preconditionFulfilledFn != nil {
+		fulfilled, err := c.preconditionFulfilledFn(ctx)
+		if err != nil {
+			return true, err
+		}
+		if !fulfilled {
+			// not ready to trigger a new revision yet; wait for a future sync to find the precondition fulfilled.
+			return false, nil
+		}
+	}
+
+	// latestKnownRevision is reserved but not yet confirmed available: either createNewRevision never ran for it
+	// (the process crashed right after UpdateLatestKnownRevisionOperatorStatus persisted it), or it ran partially.
+	// Resume that same revision number instead of incrementing past it, or its configmap/secret slot is orphaned
+	// forever and revision-status-N (if it got written) is left dangling with no copy ever confirmed for it.
 	nextRevision := latestAvailableRevision + 1
+	if latestKnownRevision > latestAvailableRevision {
+		nextRevision = latestKnownRevision
+	}
+
 	c.eventRecorder.Eventf("RevisionTriggered", "new revision %d triggered by %q", nextRevision, reason)
-	if err := c.createNewRevision(nextRevision); err != nil {
+
+	progressingCond := operatorv1.OperatorCondition{
+		Type:    "RevisionControllerProgressing",
+		Status:  operatorv1.ConditionTrue,
+		Reason:  "RevisionInProgress",
+		Message: fmt.Sprintf("writing revision %d", nextRevision),
+	}
+	if _, _, updateError := c.operatorClient.UpdateLatestKnownRevisionOperatorStatus(ctx, nextRevision, v1helpers.UpdateConditionFn(progressingCond)); updateError != nil {
+		return true, updateError
+	}
+
+	if err := c.createNewRevision(ctx, nextRevision); err != nil {
 		cond := operatorv1.OperatorCondition{
 			Type:    "RevisionControllerDegraded",
 			Status:  operatorv1.ConditionTrue,
 			Reason:  "ContentCreationError",
 			Message: err.Error(),
 		}
-		if _, _, updateError := v1helpers.UpdateStatus(c.operatorClient, v1helpers.UpdateConditionFn(cond)); updateError != nil {
+		if _, _, updateError := v1helpers.UpdateStatus(ctx, c.operatorClient, v1helpers.UpdateConditionFn(cond)); updateError != nil {
 			c.eventRecorder.Warningf("RevisionCreateFailed", "Failed to create revision %d: %v", nextRevision, err.Error())
 			return true, updateError
 		}
 		return true, nil
 	}
 
+	// re-confirm that what we just wrote actually matches the source before declaring the revision available.
+	if current, reason := c.isLatestRevisionCurrent(ctx, nextRevision); !current {
+		return true, fmt.Errorf("revision %d did not sync correctly, retrying: %s", nextRevision, reason)
+	}
+
 	cond := operatorv1.OperatorCondition{
 		Type:   "RevisionControllerDegraded",
 		Status: operatorv1.ConditionFalse,
 	}
-	if _, updated, updateError := c.operatorClient.UpdateLatestRevisionOperatorStatus(nextRevision, v1helpers.UpdateConditionFn(cond)); updateError != nil {
+	doneProgressingCond := operatorv1.OperatorCondition{
+		Type:   "RevisionControllerProgressing",
+		Status: operatorv1.ConditionFalse,
+	}
+	if _, updated, updateError := c.operatorClient.UpdateLatestRevisionOperatorStatus(ctx, nextRevision, v1helpers.UpdateConditionFn(cond), v1helpers.UpdateConditionFn(doneProgressingCond)); updateError != nil {
 		return true, updateError
 	} else if updated {
 		c.eventRecorder.Eventf("RevisionCreate", "Revision %d created because %s", latestAvailableRevision, reason)
@@ -145,17 +294,20 @@ func nameFor(name string, revision int32) string {
 }
 
 // isLatestRevisionCurrent returns whether the latest revision is up to date and an optional reason
-func (c RevisionController) isLatestRevisionCurrent(revision int32) (bool, string) {
+func (c RevisionController) isLatestRevisionCurrent(ctx context.Context, revision int32) (bool, string) {
 	configChanges := []string{}
 	for _, cm := range c.configMaps {
+		if err := ctx.Err(); err != nil {
+			return false, err.Error()
+		}
 		requiredData := map[string]string{}
 		existingData := map[string]string{}
 
-		required, err := c.configMapGetter.ConfigMaps(c.targetNamespace).Get(cm.Name, metav1.GetOptions{})
+		required, err := c.configMapGetter.ConfigMaps(c.targetNamespace).Get(ctx, cm.Name, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) && !cm.Optional {
 			return false, err.Error()
 		}
-		existing, err := c.configMapGetter.ConfigMaps(c.targetNamespace).Get(nameFor(cm.Name, revision), metav1.GetOptions{})
+		existing, err := c.configMapGetter.ConfigMaps(c.targetNamespace).Get(ctx, nameFor(cm.Name, revision), metav1.GetOptions{})
 		if apierrors.IsNotFound(err) && !cm.Optional {
 			return false, err.Error()
 		}
@@ -175,14 +327,17 @@ func (c RevisionController) isLatestRevisionCurrent(revision int32) (bool, strin
 
 	secretChanges := []string{}
 	for _, s := range c.secrets {
+		if err := ctx.Err(); err != nil {
+			return false, err.Error()
+		}
 		requiredData := map[string][]byte{}
 		existingData := map[string][]byte{}
 
-		required, err := c.secretGetter.Secrets(c.targetNamespace).Get(s.Name, metav1.GetOptions{})
+		required, err := c.secretGetter.Secrets(c.targetNamespace).Get(ctx, s.Name, metav1.GetOptions{})
 		if apierrors.IsNotFound(err) && !s.Optional {
 			return false, err.Error()
 		}
-		existing, err := c.secretGetter.Secrets(c.targetNamespace).Get(nameFor(s.Name, revision), metav1.GetOptions{})
+		existing, err := c.secretGetter.Secrets(c.targetNamespace).Get(ctx, nameFor(s.Name, revision), metav1.GetOptions{})
 		if apierrors.IsNotFound(err) && !s.Optional {
 			return false, err.Error()
 		}
@@ -200,6 +355,37 @@ func (c RevisionController) isLatestRevisionCurrent(revision int32) (bool, strin
 		}
 	}
 
+	if c.contentSourceFn != nil {
+		extraConfigMaps, extraSecrets, err := c.contentSourceFn(ctx, revision)
+		if err != nil {
+			return false, err.Error()
+		}
+		for _, cm := range extraConfigMaps {
+			existing, err := c.configMapGetter.ConfigMaps(c.targetNamespace).Get(ctx, cm.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				configChanges = append(configChanges, fmt.Sprintf("configmap/%s is missing", cm.Name))
+				continue
+			} else if err != nil {
+				return false, err.Error()
+			}
+			if !equality.Semantic.DeepEqual(existing.Data, cm.Data) {
+				configChanges = append(configChanges, fmt.Sprintf("configmap/%s has changed", cm.Name))
+			}
+		}
+		for _, s := range extraSecrets {
+			existing, err := c.secretGetter.Secrets(c.targetNamespace).Get(ctx, s.Name, metav1.GetOptions{})
+			if apierrors.IsNotFound(err) {
+				secretChanges = append(secretChanges, fmt.Sprintf("secret/%s is missing", s.Name))
+				continue
+			} else if err != nil {
+				return false, err.Error()
+			}
+			if !equality.Semantic.DeepEqual(existing.Data, s.Data) {
+				secretChanges = append(secretChanges, fmt.Sprintf("secret/%s has changed", s.Name))
+			}
+		}
+	}
+
 	if len(secretChanges) > 0 || len(configChanges) > 0 {
 		return false, strings.Join(append(secretChanges, configChanges...), ",")
 	}
@@ -207,7 +393,7 @@ func (c RevisionController) isLatestRevisionCurrent(revision int32) (bool, strin
 	return true, ""
 }
 
-func (c RevisionController) createNewRevision(revision int32) error {
+func (c RevisionController) createNewRevision(ctx context.Context, revision int32) error {
 	statusConfigMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: c.targetNamespace,
@@ -218,7 +404,7 @@ func (c RevisionController) createNewRevision(revision int32) error {
 			"revision": fmt.Sprintf("%d", revision),
 		},
 	}
-	statusConfigMap, _, err := resourceapply.ApplyConfigMap(c.configMapGetter, c.eventRecorder, statusConfigMap)
+	statusConfigMap, _, err := resourceapply.ApplyConfigMap(ctx, c.configMapGetter, c.eventRecorder, statusConfigMap)
 	if err != nil {
 		return err
 	}
@@ -230,7 +416,10 @@ func (c RevisionController) createNewRevision(revision int32) error {
 	}}
 
 	for _, cm := range c.configMaps {
-		obj, _, err := resourceapply.SyncConfigMap(c.configMapGetter, c.eventRecorder, c.targetNamespace, cm.Name, c.targetNamespace, nameFor(cm.Name, revision), ownerRefs)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		obj, _, err := resourceapply.SyncConfigMap(ctx, c.configMapGetter, c.eventRecorder, c.targetNamespace, cm.Name, c.targetNamespace, nameFor(cm.Name, revision), ownerRefs)
 		if err != nil {
 			return err
 		}
@@ -239,7 +428,10 @@ func (c RevisionController) createNewRevision(revision int32) error {
 		}
 	}
 	for _, s := range c.secrets {
-		obj, _, err := resourceapply.SyncSecret(c.secretGetter, c.eventRecorder, c.targetNamespace, s.Name, c.targetNamespace, nameFor(s.Name, revision), ownerRefs)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		obj, _, err := resourceapply.SyncSecret(ctx, c.secretGetter, c.eventRecorder, c.targetNamespace, s.Name, c.targetNamespace, nameFor(s.Name, revision), ownerRefs)
 		if err != nil {
 			return err
 		}
@@ -248,13 +440,46 @@ func (c RevisionController) createNewRevision(revision int32) error {
 		}
 	}
 
+	if c.contentSourceFn != nil {
+		extraConfigMaps, extraSecrets, err := c.contentSourceFn(ctx, revision)
+		if err != nil {
+			return err
+		}
+		for i := range extraConfigMaps {
+			cm := extraConfigMaps[i].DeepCopy()
+			cm.Namespace = c.targetNamespace
+			cm.OwnerReferences = ownerRefs
+			if _, _, err := resourceapply.ApplyConfigMap(ctx, c.configMapGetter, c.eventRecorder, cm); err != nil {
+				return err
+			}
+		}
+		for i := range extraSecrets {
+			s := extraSecrets[i].DeepCopy()
+			s.Namespace = c.targetNamespace
+			s.OwnerReferences = ownerRefs
+			if _, _, err := resourceapply.ApplySecret(ctx, c.secretGetter, c.eventRecorder, s); err != nil {
+				return err
+			}
+		}
+	}
+
+	// only now that every configmap/secret copy has landed do we mark the revision as having fully synced;
+	// a process restart before this point leaves the status configmap InProgress, so getLatestAvailableRevision
+	// will retry it instead of treating it as complete.
+	statusConfigMap.Data["status"] = "Succeeded"
+	if _, _, err := resourceapply.ApplyConfigMap(ctx, c.configMapGetter, c.eventRecorder, statusConfigMap); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // getLatestAvailableRevision returns the latest known revision to the operator
-// This is either the LatestAvailableRevision in the status or by checking revision status configmaps
-func (c RevisionController) getLatestAvailableRevision(operatorStatus *operatorv1.OperatorStatus) (int32, error) {
-	configMaps, err := c.configMapGetter.ConfigMaps(c.targetNamespace).List(metav1.ListOptions{})
+// This is either the LatestAvailableRevision in the status or by checking revision status configmaps.
+// A revision-status-N configmap whose status is not Succeeded represents an interrupted revision (e.g. the
+// process restarted mid-createNewRevision) and is skipped so that createRevisionIfNeeded retries it.
+func (c RevisionController) getLatestAvailableRevision(ctx context.Context, operatorStatus *operatorv1.OperatorStatus) (int32, error) {
+	configMaps, err := c.configMapGetter.ConfigMaps(c.targetNamespace).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return 0, err
 	}
@@ -263,6 +488,9 @@ func (c RevisionController) getLatestAvailableRevision(operatorStatus *operatorv
 		if !strings.HasPrefix(configMap.Name, "revision-status-") {
 			continue
 		}
+		if configMap.Data["status"] != "Succeeded" {
+			continue
+		}
 		if revision, ok := configMap.Data["revision"]; ok {
 			revisionNumber, err := strconv.Atoi(revision)
 			if err != nil {
@@ -277,8 +505,63 @@ func (c RevisionController) getLatestAvailableRevision(operatorStatus *operatorv
 	return latestRevision, nil
 }
 
-func (c RevisionController) sync() error {
-	operatorSpec, originalOperatorStatus, latestAvailableRevision, resourceVersion, err := c.operatorClient.GetLatestRevisionState()
+// handleRevisionDrift detects whether the copies for the current revision still exist and still match their
+// source -- catching, for example, an admin or garbage collector deleting a revision-N configmap after revision
+// N was already declared available -- and reacts according to c.driftPolicy. It emits a RevisionDrift event and
+// maintains the RevisionControllerDrift condition: True while drift is found and not healed, False again as soon
+// as the copies match their source, including right after a successful heal.
+//
+// sync() calls this against the revision that is current when the sync starts, and calls it before
+// createRevisionIfNeeded. createRevisionIfNeeded's own isLatestRevisionCurrent check cannot tell "the source
+// changed" apart from "the copies drifted out from under an unchanged source" -- it reacts to both by rolling a
+// new revision. Running the drift check first, and having DriftPolicyHeal repair the copies in place here, means
+// createRevisionIfNeeded finds the revision current again and leaves LatestAvailableRevision alone; only
+// DriftPolicyBumpRevision leaves the mismatch for createRevisionIfNeeded to resolve by bumping the revision.
+func (c RevisionController) handleRevisionDrift(ctx context.Context, latestAvailableRevision int32) error {
+	current, reason := c.isLatestRevisionCurrent(ctx, latestAvailableRevision)
+
+	driftCond := operatorv1.OperatorCondition{
+		Type:   "RevisionControllerDrift",
+		Status: operatorv1.ConditionFalse,
+	}
+	if current {
+		_, _, err := v1helpers.UpdateStatus(ctx, c.operatorClient, v1helpers.UpdateConditionFn(driftCond))
+		return err
+	}
+
+	c.eventRecorder.Warningf("RevisionDrift", "revision %d copies no longer match their source: %s", latestAvailableRevision, reason)
+	driftCond.Status = operatorv1.ConditionTrue
+	driftCond.Reason = "ContentDrifted"
+	driftCond.Message = reason
+	if _, _, err := v1helpers.UpdateStatus(ctx, c.operatorClient, v1helpers.UpdateConditionFn(driftCond)); err != nil {
+		return err
+	}
+
+	if c.driftPolicy == DriftPolicyBumpRevision {
+		// Leave the revision-N copies as-is; createRevisionIfNeeded will see isLatestRevisionCurrent(N) is
+		// still false below and roll revision N+1 instead.
+		return nil
+	}
+
+	if err := c.createNewRevision(ctx, latestAvailableRevision); err != nil {
+		return fmt.Errorf("failed to heal drift in revision %d: %w", latestAvailableRevision, err)
+	}
+
+	// Healed: clear the condition now rather than waiting for the next sync to observe it.
+	if current, _ := c.isLatestRevisionCurrent(ctx, latestAvailableRevision); current {
+		healedCond := operatorv1.OperatorCondition{
+			Type:   "RevisionControllerDrift",
+			Status: operatorv1.ConditionFalse,
+		}
+		if _, _, err := v1helpers.UpdateStatus(ctx, c.operatorClient, v1helpers.UpdateConditionFn(healedCond)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c RevisionController) sync(ctx context.Context, syncCtx factory.SyncContext) error {
+	operatorSpec, originalOperatorStatus, latestAvailableRevision, latestKnownRevision, resourceVersion, err := c.operatorClient.GetLatestRevisionState(ctx)
 	if err != nil {
 		return err
 	}
@@ -292,19 +575,28 @@ func (c RevisionController) sync() error {
 	// or possibly the operator resource was deleted and reset back to 0, which is not what we want so check configmaps
 	if latestAvailableRevision == 0 {
 		// Check to see if current revision is accurate and if not, search through configmaps for latest revision
-		latestRevision, err := c.getLatestAvailableRevision(operatorStatus)
+		latestRevision, err := c.getLatestAvailableRevision(ctx, operatorStatus)
 		if err != nil {
 			return err
 		}
 		if latestRevision != 0 {
 			// Then make sure that revision number is what's in the operator status
-			_, _, err = c.operatorClient.UpdateLatestRevisionOperatorStatus(latestRevision)
+			_, _, err = c.operatorClient.UpdateLatestRevisionOperatorStatus(ctx, latestRevision)
 			// If we made a change return and requeue with the correct status
 			return fmt.Errorf("synthetic requeue request (err: %v)", err)
 		}
 	}
 
-	requeue, syncErr := c.createRevisionIfNeeded(latestAvailableRevision, resourceVersion)
+	// Check for drift of the already-available revision, and react to it per c.driftPolicy, before
+	// createRevisionIfNeeded gets a chance to treat a drifted-but-unchanged revision the same as a real source
+	// change.
+	if latestAvailableRevision > 0 {
+		if err = c.handleRevisionDrift(ctx, latestAvailableRevision); err != nil {
+			return err
+		}
+	}
+
+	requeue, syncErr := c.createRevisionIfNeeded(ctx, latestAvailableRevision, latestKnownRevision, resourceVersion)
 	if requeue && syncErr == nil {
 		return fmt.Errorf("synthetic requeue request (err: %v)", syncErr)
 	}
@@ -320,7 +612,7 @@ func (c RevisionController) sync() error {
 		cond.Reason = "Error"
 		cond.Message = err.Error()
 	}
-	if _, _, updateError := v1helpers.UpdateStatus(c.operatorClient, v1helpers.UpdateConditionFn(cond)); updateError != nil {
+	if _, _, updateError := v1helpers.UpdateStatus(ctx, c.operatorClient, v1helpers.UpdateConditionFn(cond)); updateError != nil {
 		if err == nil {
 			return updateError
 		}
@@ -328,53 +620,3 @@ func (c RevisionController) sync() error {
 
 	return err
 }
-
-// Run starts the kube-apiserver and blocks until stopCh is closed.
-func (c *RevisionController) Run(ctx context.Context, workers int) {
-	defer utilruntime.HandleCrash()
-	defer c.queue.ShutDown()
-
-	klog.Infof("Starting RevisionController")
-	defer klog.Infof("Shutting down RevisionController")
-	if !cache.WaitForCacheSync(ctx.Done(), c.cachesToSync...) {
-		return
-	}
-
-	// doesn't matter what workers say, only start one.
-	go wait.UntilWithContext(ctx, c.runWorker, time.Second)
-
-	<-ctx.Done()
-}
-
-func (c *RevisionController) runWorker(ctx context.Context) {
-	for c.processNextWorkItem() {
-	}
-}
-
-func (c *RevisionController) processNextWorkItem() bool {
-	dsKey, quit := c.queue.Get()
-	if quit {
-		return false
-	}
-	defer c.queue.Done(dsKey)
-
-	err := c.sync()
-	if err == nil {
-		c.queue.Forget(dsKey)
-		return true
-	}
-
-	utilruntime.HandleError(fmt.Errorf("%v failed with : %v", dsKey, err))
-	c.queue.AddRateLimited(dsKey)
-
-	return true
-}
-
-// eventHandler queues the operator to check spec and status
-func (c *RevisionController) eventHandler() cache.ResourceEventHandler {
-	return cache.ResourceEventHandlerFuncs{
-		AddFunc:    func(obj interface{}) { c.queue.Add(revisionControllerWorkQueueKey) },
-		UpdateFunc: func(old, new interface{}) { c.queue.Add(revisionControllerWorkQueueKey) },
-		DeleteFunc: func(obj interface{}) { c.queue.Add(revisionControllerWorkQueueKey) },
-	}
-}
@@ -0,0 +1,312 @@
+package revisioncontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+
+	"github.com/openshift/library-go/pkg/operator/events"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+)
+
+// fakeLatestRevisionClient is a minimal, in-memory LatestRevisionClient used to drive sync() directly in tests,
+// without standing up a real operator CR or informer.
+type fakeLatestRevisionClient struct {
+	spec   *operatorv1.OperatorSpec
+	status *operatorv1.OperatorStatus
+
+	latestAvailableRevision int32
+	latestKnownRevision     int32
+}
+
+func newFakeLatestRevisionClient() *fakeLatestRevisionClient {
+	return &fakeLatestRevisionClient{
+		spec:   &operatorv1.OperatorSpec{ManagementState: operatorv1.Managed},
+		status: &operatorv1.OperatorStatus{},
+	}
+}
+
+func (f *fakeLatestRevisionClient) Informer() cache.SharedIndexInformer { return nil }
+
+func (f *fakeLatestRevisionClient) GetObjectMeta() (*metav1.ObjectMeta, error) {
+	return &metav1.ObjectMeta{}, nil
+}
+
+func (f *fakeLatestRevisionClient) GetOperatorState() (*operatorv1.OperatorSpec, *operatorv1.OperatorStatus, string, error) {
+	return f.spec, f.status, "1", nil
+}
+
+func (f *fakeLatestRevisionClient) UpdateOperatorSpec(_ context.Context, _ string, in *operatorv1.OperatorSpec) (*operatorv1.OperatorSpec, string, error) {
+	f.spec = in
+	return f.spec, "1", nil
+}
+
+func (f *fakeLatestRevisionClient) UpdateOperatorStatus(_ context.Context, in *operatorv1.OperatorStatus) (*operatorv1.OperatorStatus, error) {
+	f.status = in
+	return f.status, nil
+}
+
+func (f *fakeLatestRevisionClient) GetLatestRevisionState(_ context.Context) (*operatorv1.OperatorSpec, *operatorv1.OperatorStatus, int32, int32, string, error) {
+	return f.spec, f.status, f.latestAvailableRevision, f.latestKnownRevision, "1", nil
+}
+
+func (f *fakeLatestRevisionClient) UpdateLatestRevisionOperatorStatus(_ context.Context, latestAvailableRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error) {
+	f.latestAvailableRevision = latestAvailableRevision
+	for _, update := range updateFuncs {
+		if err := update(f.status); err != nil {
+			return f.status, false, err
+		}
+	}
+	return f.status, true, nil
+}
+
+func (f *fakeLatestRevisionClient) UpdateLatestKnownRevisionOperatorStatus(_ context.Context, latestKnownRevision int32, updateFuncs ...v1helpers.UpdateStatusFunc) (*operatorv1.OperatorStatus, bool, error) {
+	f.latestKnownRevision = latestKnownRevision
+	for _, update := range updateFuncs {
+		if err := update(f.status); err != nil {
+			return f.status, false, err
+		}
+	}
+	return f.status, true, nil
+}
+
+func newTestController(t *testing.T, client *fakeLatestRevisionClient, kubeClient *fake.Clientset) *RevisionController {
+	t.Helper()
+	return &RevisionController{
+		targetNamespace: "test",
+		configMaps:      []RevisionResource{{Name: "config"}},
+		operatorClient:  client,
+		configMapGetter: kubeClient.CoreV1(),
+		secretGetter:    kubeClient.CoreV1(),
+		eventRecorder:   events.NewInMemoryRecorder("revisioncontroller-test"),
+	}
+}
+
+func createConfigMap(t *testing.T, kubeClient *fake.Clientset, name string, data map[string]string) {
+	t.Helper()
+	_, err := kubeClient.CoreV1().ConfigMaps("test").Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: name},
+		Data:       data,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to seed configmap %q: %v", name, err)
+	}
+}
+
+func getConfigMap(t *testing.T, kubeClient *fake.Clientset, name string) *corev1.ConfigMap {
+	t.Helper()
+	cm, err := kubeClient.CoreV1().ConfigMaps("test").Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected configmap %q to exist: %v", name, err)
+	}
+	return cm
+}
+
+func TestSyncCreatesFirstRevision(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("expected LatestAvailableRevision=1, got %d", client.latestAvailableRevision)
+	}
+	if got := getConfigMap(t, kubeClient, "config-1").Data["k"]; got != "v1" {
+		t.Fatalf("expected config-1 to carry source data, got %q", got)
+	}
+	status := getConfigMap(t, kubeClient, "revision-status-1")
+	if status.Data["status"] != "Succeeded" {
+		t.Fatalf("expected revision-status-1 to be Succeeded, got %q", status.Data["status"])
+	}
+}
+
+func TestSyncBlocksOnPrecondition(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+	c.preconditionFulfilledFn = func(ctx context.Context) (bool, error) { return false, nil }
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.latestAvailableRevision != 0 {
+		t.Fatalf("expected no revision to be created while precondition is unfulfilled, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+	if _, err := kubeClient.CoreV1().ConfigMaps("test").Get(context.TODO(), "config-1", metav1.GetOptions{}); err == nil {
+		t.Fatalf("expected config-1 to not exist while precondition is unfulfilled")
+	}
+}
+
+// TestSyncResumesInterruptedRevision covers a process restart that persisted LatestKnownRevision and an
+// InProgress revision-status configmap but never got to sync the revision's copies.
+func TestSyncResumesInterruptedRevision(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+	_, err := kubeClient.CoreV1().ConfigMaps("test").Create(context.TODO(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "revision-status-1"},
+		Data:       map[string]string{"status": "InProgress", "revision": "1"},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to seed revision-status-1: %v", err)
+	}
+
+	client := newFakeLatestRevisionClient()
+	client.latestKnownRevision = 1
+	c := newTestController(t, client, kubeClient)
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("expected the interrupted revision 1 to be resumed and confirmed, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+	if got := getConfigMap(t, kubeClient, "config-1").Data["k"]; got != "v1" {
+		t.Fatalf("expected config-1 to be synced while resuming revision 1, got %q", got)
+	}
+}
+
+func TestSyncHealsDriftByDefault(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error creating first revision: %v", err)
+	}
+
+	if err := kubeClient.CoreV1().ConfigMaps("test").Delete(context.TODO(), "config-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to simulate drift by deleting config-1: %v", err)
+	}
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error healing drift: %v", err)
+	}
+
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("DriftPolicyHeal must not bump the revision number, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+	if got := getConfigMap(t, kubeClient, "config-1").Data["k"]; got != "v1" {
+		t.Fatalf("expected config-1 to be healed back in place, got %q", got)
+	}
+}
+
+func TestSyncBumpsRevisionOnDriftWhenConfigured(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+	c.driftPolicy = DriftPolicyBumpRevision
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error creating first revision: %v", err)
+	}
+
+	if err := kubeClient.CoreV1().ConfigMaps("test").Delete(context.TODO(), "config-1", metav1.DeleteOptions{}); err != nil {
+		t.Fatalf("failed to simulate drift by deleting config-1: %v", err)
+	}
+
+	// handleRevisionDrift records the drift but, with DriftPolicyBumpRevision, deliberately leaves config-1
+	// unhealed; createRevisionIfNeeded then sees revision 1 is no longer current and rolls a new one in the
+	// same sync.
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error bumping revision after drift: %v", err)
+	}
+	if client.latestAvailableRevision != 2 {
+		t.Fatalf("DriftPolicyBumpRevision should trigger revision 2, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+}
+
+func TestSyncAppliesContentSourceFunc(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+	c.contentSourceFn = func(_ context.Context, revision int32) ([]corev1.ConfigMap, []corev1.Secret, error) {
+		extra := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "extra"},
+			Data:       map[string]string{"revision": fmt.Sprintf("%d", revision)},
+		}
+		return []corev1.ConfigMap{extra}, nil, nil
+	}
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("expected LatestAvailableRevision=1, got %d", client.latestAvailableRevision)
+	}
+	if got := getConfigMap(t, kubeClient, "extra").Data["revision"]; got != "1" {
+		t.Fatalf("expected extra content from ContentSourceFunc to be applied, got %q", got)
+	}
+
+	// A second sync with the same (revision-keyed) content must find everything current and not bump.
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error on resync: %v", err)
+	}
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("unchanged ContentSourceFunc output must not trigger a new revision, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+}
+
+// TestContentSourceFuncMustBeRevisionStable pins down the failure mode documented on ContentSourceFunc:
+// isLatestRevisionCurrent re-invokes it for a revision under check, including an old, already-settled one. A
+// ContentSourceFunc that reflects mutable external state instead of being a pure function of the revision number
+// will make that settled revision look like it drifted, and DriftPolicyHeal will overwrite its content with the
+// newer state rather than leaving the frozen revision alone.
+func TestContentSourceFuncMustBeRevisionStable(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	createConfigMap(t, kubeClient, "config", map[string]string{"k": "v1"})
+
+	client := newFakeLatestRevisionClient()
+	c := newTestController(t, client, kubeClient)
+	state := "a"
+	c.contentSourceFn = func(_ context.Context, _ int32) ([]corev1.ConfigMap, []corev1.Secret, error) {
+		extra := corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "extra"},
+			Data:       map[string]string{"state": state},
+		}
+		return []corev1.ConfigMap{extra}, nil, nil
+	}
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error creating first revision: %v", err)
+	}
+	if got := getConfigMap(t, kubeClient, "extra").Data["state"]; got != "a" {
+		t.Fatalf("expected extra to carry the state at revision creation time, got %q", got)
+	}
+
+	// Nothing about revision 1's declared sources changed; only the external state the (misused) ContentSourceFunc
+	// happens to read did.
+	state = "b"
+
+	if err := c.sync(context.TODO(), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if client.latestAvailableRevision != 1 {
+		t.Fatalf("a non-revision-stable ContentSourceFunc should not have bumped the revision, got LatestAvailableRevision=%d", client.latestAvailableRevision)
+	}
+	if got := getConfigMap(t, kubeClient, "extra").Data["state"]; got != "b" {
+		t.Fatalf("expected DriftPolicyHeal to have overwritten revision 1's frozen content with the newer state, got %q", got)
+	}
+}